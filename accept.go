@@ -0,0 +1,287 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package accept provides functionality for parsing and negotiating the
+// HTTP Accept header, as defined in RFC 2616 Section 14.1.
+package accept
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Param is a single "key=value" Accept parameter, in the order it
+// appeared in the header.
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Accept represents a single entry of a parsed Accept header. Parameters
+// holds the media-type parameters that appear before the "q" parameter
+// (e.g. "level" in "text/html;level=1;q=0.5"); Extensions holds any
+// accept-extension parameters that appear after it. Both are nil, rather
+// than allocated empty, when no such parameters are present.
+type Accept struct {
+	Type       string
+	Subtype    string
+	Q          float64
+	Parameters []Param
+	Extensions []Param
+}
+
+// AcceptSlice is a slice of Accept entries, sorted by precedence: highest
+// quality first, with ties broken by specificity (concrete type/subtype
+// before wildcards) and then by the number of parameters.
+type AcceptSlice []Accept
+
+// Parse parses a raw Accept header value into an AcceptSlice, sorted by
+// precedence. Entries that cannot be parsed are silently discarded. An
+// empty header is treated as "*/*", meaning everything is accepted. A
+// header containing an unterminated quoted-string yields no entries at
+// all, including any well-formed entries before it.
+func Parse(header string) AcceptSlice {
+	var accepted AcceptSlice
+	ParseInto(header, &accepted)
+	return accepted
+}
+
+// ParseInto parses header the same way Parse does, but appends into the
+// AcceptSlice pointed to by dst after truncating it to length zero,
+// reusing its backing array across calls instead of allocating a new
+// one. This is intended for hot paths that parse many headers in
+// sequence, e.g. one per incoming request.
+//
+// Individual entries that fail to parse are silently discarded, same as
+// Parse. The only error ParseInto can return is one reporting that header
+// itself could not be split into entries at all, e.g. an unterminated
+// quoted string; dst is left truncated to length zero in that case.
+func ParseInto(header string, dst *AcceptSlice) error {
+	accepted := (*dst)[:0]
+
+	parts, ok := splitUnquoted(header, ',')
+	if !ok {
+		*dst = accepted
+		return &ParseError{Fragment: header, Reason: ReasonUnterminatedQuotedString}
+	}
+	for _, part := range parts {
+		a, err := parseAccept(part)
+		if err != nil {
+			continue
+		}
+		accepted = append(accepted, a)
+	}
+	if header == "" {
+		accepted = append(accepted, Accept{Type: "*", Subtype: "*", Q: 1})
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].Less(accepted[j]) })
+	*dst = accepted
+	return nil
+}
+
+// ParseStrict is identical to Parse, except that it rejects the entire
+// header at the first entry that fails to parse, returning a *ParseError
+// describing why. Use ParseStrict when malformed input should be
+// rejected or audited rather than silently dropped.
+func ParseStrict(header string) (AcceptSlice, error) {
+	if header == "" {
+		return AcceptSlice{{Type: "*", Subtype: "*", Q: 1}}, nil
+	}
+
+	parts, ok := splitUnquoted(header, ',')
+	if !ok {
+		return nil, &ParseError{Fragment: header, Reason: ReasonUnterminatedQuotedString}
+	}
+
+	accepted := make(AcceptSlice, 0, len(parts))
+	offset := 0
+	for position, part := range parts {
+		a, err := parseAccept(part)
+		if err != nil {
+			err.Position = position
+			err.Offset = offset
+			return nil, err
+		}
+		accepted = append(accepted, a)
+		offset += len(part) + 1
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].Less(accepted[j]) })
+	return accepted, nil
+}
+
+// parseAccept parses a single comma-separated entry of an Accept header.
+func parseAccept(part string) (Accept, *ParseError) {
+	fields, ok := splitUnquoted(part, ';')
+	if !ok || len(fields) == 0 {
+		return Accept{}, &ParseError{Fragment: part, Reason: ReasonUnterminatedQuotedString}
+	}
+
+	mediaRange := strings.SplitN(fields[0], "/", 2)
+	if len(mediaRange) != 2 {
+		return Accept{}, &ParseError{Fragment: fields[0], Reason: ReasonInvalidToken}
+	}
+	accepted := Accept{
+		Type:    strings.ToLower(strings.TrimSpace(mediaRange[0])),
+		Subtype: strings.ToLower(strings.TrimSpace(mediaRange[1])),
+		Q:       1,
+	}
+	if accepted.Type == "" || accepted.Subtype == "" {
+		return Accept{}, &ParseError{Fragment: fields[0], Reason: ReasonInvalidToken}
+	}
+
+	sawQ := false
+	for _, field := range fields[1:] {
+		key, value, ok := parseParam(field)
+		if !ok {
+			return Accept{}, &ParseError{Fragment: field, Reason: ReasonInvalidToken}
+		}
+		key = strings.ToLower(key)
+
+		if key == "q" {
+			if sawQ {
+				return Accept{}, &ParseError{Fragment: field, Reason: ReasonDuplicateParameter}
+			}
+			q, ok := parseQ(value)
+			if !ok {
+				return Accept{}, &ParseError{Fragment: field, Reason: ReasonInvalidQValue}
+			}
+			accepted.Q = q
+			sawQ = true
+			continue
+		}
+
+		// Most Accept headers carry no parameters at all, so the slice
+		// backing Parameters/Extensions is only allocated once one is
+		// actually seen, rather than eagerly allocating a map per entry.
+		params := &accepted.Parameters
+		if sawQ {
+			params = &accepted.Extensions
+		}
+		if _, exists := paramValue(*params, key); exists {
+			return Accept{}, &ParseError{Fragment: field, Reason: ReasonDuplicateParameter}
+		}
+		*params = append(*params, Param{Key: key, Value: value})
+	}
+
+	return accepted, nil
+}
+
+// parseQ parses the value of a "q" parameter, clamping values greater
+// than 1 down to 1, as some browsers are known to send malformed qvalues.
+// A negative or otherwise unparseable value is rejected.
+func parseQ(value string) (float64, bool) {
+	q, err := strconv.ParseFloat(value, 64)
+	if err != nil || q < 0 {
+		return 0, false
+	}
+	if q > 1 {
+		q = 1
+	}
+	return q, true
+}
+
+// Less reports whether a should sort before b: higher quality first, then
+// concrete types before wildcards, then concrete subtypes before
+// wildcards, then more parameters before fewer.
+func (a Accept) Less(b Accept) bool {
+	if a.Q != b.Q {
+		return a.Q > b.Q
+	}
+	if (a.Type == "*") != (b.Type == "*") {
+		return b.Type == "*"
+	}
+	if (a.Subtype == "*") != (b.Subtype == "*") {
+		return b.Subtype == "*"
+	}
+	return len(a.Parameters) > len(b.Parameters)
+}
+
+// splitType splits a "type/subtype;param=value" string into its type,
+// subtype, and parameters. If ctype contains no "/", it is treated as a
+// bare type with a wildcard subtype. A type or subtype left empty by the
+// split (e.g. "/xml" or "text/") is treated as "*". Type and subtype are
+// lowercased, matching the case-insensitive comparison used by Parse.
+func splitType(ctype string) (reqType, reqSubtype string, params []Param) {
+	fields, ok := splitUnquoted(ctype, ';')
+	if !ok || len(fields) == 0 {
+		fields = []string{ctype}
+	}
+
+	mediaRange := fields[0]
+	if !strings.Contains(mediaRange, "/") {
+		reqType, reqSubtype = mediaRange, "*"
+	} else {
+		parts := strings.SplitN(mediaRange, "/", 2)
+		reqType, reqSubtype = parts[0], parts[1]
+		if reqType == "" {
+			reqType = "*"
+		}
+		if reqSubtype == "" {
+			reqSubtype = "*"
+		}
+	}
+	reqType = strings.ToLower(strings.TrimSpace(reqType))
+	reqSubtype = strings.ToLower(strings.TrimSpace(reqSubtype))
+
+	for _, field := range fields[1:] {
+		key, value, ok := parseParam(field)
+		if !ok {
+			continue
+		}
+		params = append(params, Param{Key: strings.ToLower(key), Value: value})
+	}
+
+	return reqType, reqSubtype, params
+}
+
+// matches reports whether an Accept component matches a requested
+// component, where "*" on either side matches anything.
+func matches(accepted, requested string) bool {
+	return accepted == "*" || requested == "*" || accepted == requested
+}
+
+// Accepts reports whether ctype is accepted by the AcceptSlice. ctype may
+// itself contain wildcards, e.g. "text/*", and parameters, e.g.
+// "text/html;level=1". An Accept entry with parameters of its own only
+// matches a ctype carrying identical values for those parameters.
+func (accepted AcceptSlice) Accepts(ctype string) bool {
+	reqType, reqSubtype, reqParams := splitType(ctype)
+	for _, a := range accepted {
+		if matches(a.Type, reqType) && matches(a.Subtype, reqSubtype) && paramsMatch(a.Parameters, reqParams) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNoAcceptableType is returned by Negotiate when none of the provided
+// types are acceptable according to the Accept header.
+var ErrNoAcceptableType = errors.New("accept: none of the provided types are acceptable")
+
+// Negotiate determines which of the given types is the most preferred,
+// according to header. Types are considered in order of the header's
+// precedence, and ties within the header are broken by the order the
+// types were passed in. If types is empty, Negotiate returns an empty
+// string and a nil error.
+func Negotiate(header string, types ...string) (string, error) {
+	if len(types) == 0 {
+		return "", nil
+	}
+
+	accepted := Parse(header)
+	for _, a := range accepted {
+		for _, t := range types {
+			reqType, reqSubtype, reqParams := splitType(t)
+			if matches(a.Type, reqType) && matches(a.Subtype, reqSubtype) && paramsMatch(a.Parameters, reqParams) {
+				return t, nil
+			}
+		}
+	}
+
+	return "", ErrNoAcceptableType
+}
@@ -20,10 +20,9 @@ func TestParse(t *testing.T) {
 			input: "",
 			output: AcceptSlice{
 				{ // 0
-					Type:       "*",
-					Subtype:    "*",
-					Q:          1,
-					Extensions: map[string]string{},
+					Type:    "*",
+					Subtype: "*",
+					Q:       1,
 				},
 			},
 		},
@@ -32,28 +31,24 @@ func TestParse(t *testing.T) {
 			input: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
 			output: AcceptSlice{
 				{ // 0
-					Type:       "text",
-					Subtype:    "html",
-					Q:          1,
-					Extensions: map[string]string{},
+					Type:    "text",
+					Subtype: "html",
+					Q:       1,
 				},
 				{ // 1
-					Type:       "application",
-					Subtype:    "xhtml+xml",
-					Q:          1,
-					Extensions: map[string]string{},
+					Type:    "application",
+					Subtype: "xhtml+xml",
+					Q:       1,
 				},
 				{ // 2
-					Type:       "application",
-					Subtype:    "xml",
-					Q:          0.9,
-					Extensions: map[string]string{},
+					Type:    "application",
+					Subtype: "xml",
+					Q:       0.9,
 				},
 				{ // 3
-					Type:       "*",
-					Subtype:    "*",
-					Q:          0.8,
-					Extensions: map[string]string{},
+					Type:    "*",
+					Subtype: "*",
+					Q:       0.8,
 				},
 			},
 		},
@@ -70,28 +65,24 @@ func TestParse(t *testing.T) {
 					,  *  /  *  ;  q  =  0.8`,
 			output: AcceptSlice{
 				{ // 0
-					Type:       "text",
-					Subtype:    "html",
-					Q:          1,
-					Extensions: map[string]string{},
+					Type:    "text",
+					Subtype: "html",
+					Q:       1,
 				},
 				{ // 1
-					Type:       "application",
-					Subtype:    "xhtml+xml",
-					Q:          1,
-					Extensions: map[string]string{},
+					Type:    "application",
+					Subtype: "xhtml+xml",
+					Q:       1,
 				},
 				{ // 2
-					Type:       "application",
-					Subtype:    "xml",
-					Q:          0.9,
-					Extensions: map[string]string{},
+					Type:    "application",
+					Subtype: "xml",
+					Q:       0.9,
 				},
 				{ // 3
-					Type:       "*",
-					Subtype:    "*",
-					Q:          0.8,
-					Extensions: map[string]string{},
+					Type:    "*",
+					Subtype: "*",
+					Q:       0.8,
 				},
 			},
 		},
@@ -100,10 +91,9 @@ func TestParse(t *testing.T) {
 			input: "text/html;q=1.05,application/xhtml+xml;q=-1.05,application/xml;q=1.0=0.5,*/*;q=INVALID",
 			output: AcceptSlice{
 				{ // 0
-					Type:       "text",
-					Subtype:    "html",
-					Q:          1,
-					Extensions: map[string]string{},
+					Type:    "text",
+					Subtype: "html",
+					Q:       1,
 				},
 			},
 		},
@@ -115,93 +105,89 @@ func TestParse(t *testing.T) {
 					Type:    "text",
 					Subtype: "plain",
 					Q:       1,
-					Extensions: map[string]string{
-						"a": "1",
-						"b": "1",
+					Parameters: []Param{
+						{Key: "a", Value: "1"},
+						{Key: "b", Value: "1"},
 					},
 				},
 				{ // 1
 					Type:    "text",
 					Subtype: "plain",
 					Q:       1,
-					Extensions: map[string]string{
-						"a": "1",
+					Parameters: []Param{
+						{Key: "a", Value: "1"},
 					},
 				},
 				{ // 2
-					Type:       "text",
-					Subtype:    "plain",
-					Q:          1,
-					Extensions: map[string]string{},
+					Type:    "text",
+					Subtype: "plain",
+					Q:       1,
 				},
 				{ // 3
 					Type:    "text",
 					Subtype: "*",
 					Q:       1,
-					Extensions: map[string]string{
-						"a": "1",
-						"b": "1",
+					Parameters: []Param{
+						{Key: "a", Value: "1"},
+						{Key: "b", Value: "1"},
 					},
 				},
 				{ // 4
 					Type:    "text",
 					Subtype: "*",
 					Q:       1,
-					Extensions: map[string]string{
-						"a": "1",
+					Parameters: []Param{
+						{Key: "a", Value: "1"},
 					},
 				},
 				{ // 5
-					Type:       "text",
-					Subtype:    "*",
-					Q:          1,
-					Extensions: map[string]string{},
+					Type:    "text",
+					Subtype: "*",
+					Q:       1,
 				},
 				{ // 6
 					Type:    "*",
 					Subtype: "plain",
 					Q:       1,
-					Extensions: map[string]string{
-						"a": "1",
-						"b": "1",
+					Parameters: []Param{
+						{Key: "a", Value: "1"},
+						{Key: "b", Value: "1"},
 					},
 				},
 				{ // 7
 					Type:    "*",
 					Subtype: "plain",
 					Q:       1,
-					Extensions: map[string]string{
-						"a": "1",
+					Parameters: []Param{
+						{Key: "a", Value: "1"},
 					},
 				},
 				{ // 8
-					Type:       "*",
-					Subtype:    "plain",
-					Q:          1,
-					Extensions: map[string]string{},
+					Type:    "*",
+					Subtype: "plain",
+					Q:       1,
 				},
 				{ // 9
 					Type:    "*",
 					Subtype: "*",
 					Q:       1,
-					Extensions: map[string]string{
-						"a": "1",
-						"b": "1",
+					Parameters: []Param{
+						{Key: "a", Value: "1"},
+						{Key: "b", Value: "1"},
 					},
 				},
 				{ // 10
 					Type:    "*",
 					Subtype: "*",
 					Q:       1,
-					Extensions: map[string]string{
-						"a": "1",
+					Parameters: []Param{
+						{Key: "a", Value: "1"},
 					},
 				},
 				{ // 11
-					Type:       "*",
-					Subtype:    "*",
-					Q:          1,
-					Extensions: map[string]string{},
+					Type:    "*",
+					Subtype: "*",
+					Q:       1,
 				},
 			},
 		},
@@ -224,7 +210,10 @@ func TestParse(t *testing.T) {
 			if a.Q != test.output[i].Q {
 				t.Errorf("Parse (%d.%d): expected qval '%v', received '%v'.", testPos, i, test.output[i].Q, a.Q)
 			}
-			if !mapsAreSimilar(a.Extensions, test.output[i].Extensions) {
+			if !paramsAreSimilar(a.Parameters, test.output[i].Parameters) {
+				t.Errorf("Parse (%d.%d): expected parameters '%v', received '%v'.", testPos, i, test.output[i].Parameters, a.Parameters)
+			}
+			if !paramsAreSimilar(a.Extensions, test.output[i].Extensions) {
 				t.Errorf("Parse (%d.%d): expected extensions '%v', received '%v'.", testPos, i, test.output[i].Extensions, a.Extensions)
 			}
 		}
@@ -377,16 +366,56 @@ func TestAccepts(t *testing.T) {
 	}
 }
 
+func TestParseQuotedParameters(t *testing.T) {
+	accepted := Parse(`text/html;charset="utf-8;foo";q=0.5, TEXT/PLAIN;Level=1`)
+	if len(accepted) != 2 {
+		t.Fatalf("ParseQuotedParameters: expected 2 elements, received %d.", len(accepted))
+	}
+
+	// The quoted-string's embedded ";" must not be treated as a
+	// parameter separator, and the case of the type/subtype/param name
+	// is folded to lowercase.
+	plain := accepted[0]
+	if plain.Type != "text" || plain.Subtype != "plain" {
+		t.Errorf("ParseQuotedParameters: expected type 'text/plain', received '%v/%v'.", plain.Type, plain.Subtype)
+	}
+	if value, _ := paramValue(plain.Parameters, "level"); value != "1" {
+		t.Errorf("ParseQuotedParameters: expected parameter level=1, received '%v'.", value)
+	}
+
+	html := accepted[1]
+	if html.Q != 0.5 {
+		t.Errorf("ParseQuotedParameters: expected qval 0.5, received %v.", html.Q)
+	}
+	if value, _ := paramValue(html.Parameters, "charset"); value != "utf-8;foo" {
+		t.Errorf("ParseQuotedParameters: expected parameter charset='utf-8;foo', received '%v'.", value)
+	}
+}
+
+func TestAcceptsParameters(t *testing.T) {
+	accepted := Parse("text/html;level=1")
+
+	if !accepted.Accepts("text/html;level=1") {
+		t.Errorf("Accepts: expected 'text/html;level=1' to be accepted.")
+	}
+	if accepted.Accepts("text/html;level=2") {
+		t.Errorf("Accepts: expected 'text/html;level=2' to not be accepted.")
+	}
+	if accepted.Accepts("text/html") {
+		t.Errorf("Accepts: expected 'text/html' to not be accepted.")
+	}
+}
+
 //
 // Utility functions
 //
 
-func mapsAreSimilar(a, b map[string]string) bool {
+func paramsAreSimilar(a, b []Param) bool {
 	if len(a) != len(b) {
 		return false
 	}
-	for aKey, aVal := range a {
-		if bVal, exists := b[aKey]; !exists || aVal != bVal {
+	for _, p := range a {
+		if value, exists := paramValue(b, p.Key); !exists || value != p.Value {
 			return false
 		}
 	}
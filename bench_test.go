@@ -0,0 +1,55 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import "testing"
+
+// These headers are representative of what real user agents send, and
+// are used to benchmark the common case rather than worst-case input.
+const (
+	chromeHeader  = "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7"
+	firefoxHeader = "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8"
+	curlHeader    = "*/*"
+)
+
+func BenchmarkParseChrome(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Parse(chromeHeader)
+	}
+}
+
+func BenchmarkParseFirefox(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Parse(firefoxHeader)
+	}
+}
+
+func BenchmarkParseCurl(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Parse(curlHeader)
+	}
+}
+
+func BenchmarkParseIntoChrome(b *testing.B) {
+	var dst AcceptSlice
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParseInto(chromeHeader, &dst)
+	}
+}
+
+func BenchmarkParserChrome(b *testing.B) {
+	var p Parser
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Release(p.Parse(chromeHeader))
+	}
+}
+
+func BenchmarkNegotiateChrome(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Negotiate(chromeHeader, "text/html", "application/xml")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import (
+	"sort"
+	"strings"
+)
+
+// Charset represents a single entry of a parsed Accept-Charset header.
+type Charset struct {
+	Charset string
+	Q       float64
+}
+
+// CharsetSlice is a slice of Charset entries, sorted by precedence:
+// highest quality first, with ties broken by specificity (a concrete
+// charset before "*").
+type CharsetSlice []Charset
+
+// ParseCharset parses a raw Accept-Charset header value into a
+// CharsetSlice, sorted by precedence. Entries that cannot be parsed are
+// silently discarded. An empty header is treated as "*", meaning every
+// charset is accepted.
+func ParseCharset(header string) CharsetSlice {
+	parts := strings.Split(header, ",")
+	accepted := make(CharsetSlice, 0, len(parts))
+	for _, part := range parts {
+		c, ok := parseCharset(part)
+		if !ok {
+			continue
+		}
+		accepted = append(accepted, c)
+	}
+	if header == "" {
+		accepted = append(accepted, Charset{Charset: "*", Q: 1})
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].Less(accepted[j]) })
+	return accepted
+}
+
+// parseCharset parses a single comma-separated entry of an
+// Accept-Charset header.
+func parseCharset(part string) (Charset, bool) {
+	fields := strings.Split(part, ";")
+
+	charset := strings.TrimSpace(fields[0])
+	if charset == "" {
+		return Charset{}, false
+	}
+	accepted := Charset{Charset: charset, Q: 1}
+
+	for _, field := range fields[1:] {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) != "q" {
+			return Charset{}, false
+		}
+		q, ok := parseQ(strings.TrimSpace(kv[1]))
+		if !ok {
+			return Charset{}, false
+		}
+		accepted.Q = q
+	}
+
+	return accepted, true
+}
+
+// Less reports whether a should sort before b: higher quality first, then
+// a concrete charset before "*".
+func (a Charset) Less(b Charset) bool {
+	if a.Q != b.Q {
+		return a.Q > b.Q
+	}
+	return b.Charset == "*" && a.Charset != "*"
+}
+
+// Accepts reports whether charset is accepted by the CharsetSlice. The
+// comparison is case-insensitive, as charset names are.
+func (accepted CharsetSlice) Accepts(charset string) bool {
+	for _, c := range accepted {
+		if c.Charset == "*" || strings.EqualFold(c.Charset, charset) {
+			return c.Q > 0
+		}
+	}
+	return false
+}
+
+// NegotiateCharset determines which of the given charsets is the most
+// preferred, according to header. Charsets are considered in order of the
+// header's precedence, and ties within the header are broken by the
+// order the charsets were passed in. If charsets is empty,
+// NegotiateCharset returns an empty string and a nil error.
+func NegotiateCharset(header string, charsets ...string) (string, error) {
+	if len(charsets) == 0 {
+		return "", nil
+	}
+
+	accepted := ParseCharset(header)
+	for _, a := range accepted {
+		if a.Q == 0 {
+			continue
+		}
+		for _, c := range charsets {
+			if a.Charset == "*" || strings.EqualFold(a.Charset, c) {
+				return c, nil
+			}
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import "testing"
+
+func TestParseCharset(t *testing.T) {
+	type parseTest struct {
+		input  string
+		output CharsetSlice
+	}
+
+	parseTests := []parseTest{
+		{ // 0
+			input:  "",
+			output: CharsetSlice{{Charset: "*", Q: 1}},
+		},
+		{ // 1
+			input: "iso-8859-5, unicode-1-1;q=0.8, *;q=0.1",
+			output: CharsetSlice{
+				{Charset: "iso-8859-5", Q: 1},
+				{Charset: "unicode-1-1", Q: 0.8},
+				{Charset: "*", Q: 0.1},
+			},
+		},
+	}
+
+	for testPos, test := range parseTests {
+		accepted := ParseCharset(test.input)
+		if len(accepted) != len(test.output) {
+			t.Errorf("ParseCharset (%d): expected %d elements, received %d.", testPos, len(test.output), len(accepted))
+			continue
+		}
+		for i, c := range accepted {
+			if c.Charset != test.output[i].Charset || c.Q != test.output[i].Q {
+				t.Errorf("ParseCharset (%d.%d): expected '%v;q=%v', received '%v;q=%v'.", testPos, i, test.output[i].Charset, test.output[i].Q, c.Charset, c.Q)
+			}
+		}
+	}
+}
+
+func TestCharsetAccepts(t *testing.T) {
+	accepted := ParseCharset("UTF-8, iso-8859-1;q=0.5")
+
+	acceptsTrue := []string{"utf-8", "UTF-8", "ISO-8859-1"}
+	for i, charset := range acceptsTrue {
+		if !accepted.Accepts(charset) {
+			t.Errorf("Accepts (%d): expected '%v' to be accepted.", i, charset)
+		}
+	}
+
+	if accepted.Accepts("shift-jis") {
+		t.Errorf("Accepts: expected 'shift-jis' to not be accepted.")
+	}
+}
+
+func TestNegotiateCharset(t *testing.T) {
+	type negotiateTest struct {
+		header   string
+		charsets []string
+		expected string
+	}
+
+	negotiateTests := []negotiateTest{
+		{ // 0
+			header:   "utf-8, iso-8859-1;q=0.5",
+			charsets: []string{},
+			expected: "",
+		},
+		{ // 1
+			header:   "iso-8859-1;q=0.5, utf-8",
+			charsets: []string{"iso-8859-1", "utf-8"},
+			expected: "utf-8",
+		},
+		{ // 2
+			header:   "shift-jis",
+			charsets: []string{"utf-8"},
+			expected: "",
+		},
+	}
+
+	for i, test := range negotiateTests {
+		result, err := NegotiateCharset(test.header, test.charsets...)
+		if err != nil {
+			t.Errorf("NegotiateCharset (%d): expected no error, received '%v'.", i, err)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("NegotiateCharset (%d): expected charset '%v', received '%v'.", i, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import (
+	"sort"
+	"strings"
+)
+
+// Encoding represents a single entry of a parsed Accept-Encoding header.
+type Encoding struct {
+	Coding string
+	Q      float64
+}
+
+// EncodingSlice is a slice of Encoding entries, sorted by precedence:
+// highest quality first, with ties broken by specificity (a concrete
+// coding before "*").
+type EncodingSlice []Encoding
+
+// ParseEncoding parses a raw Accept-Encoding header value into an
+// EncodingSlice, sorted by precedence. Entries that cannot be parsed are
+// silently discarded. An empty header is treated as accepting any
+// encoding, including identity.
+func ParseEncoding(header string) EncodingSlice {
+	parts := strings.Split(header, ",")
+	accepted := make(EncodingSlice, 0, len(parts))
+	for _, part := range parts {
+		e, ok := parseEncoding(part)
+		if !ok {
+			continue
+		}
+		accepted = append(accepted, e)
+	}
+	if header == "" {
+		accepted = append(accepted, Encoding{Coding: "*", Q: 1})
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].Less(accepted[j]) })
+	return accepted
+}
+
+// parseEncoding parses a single comma-separated entry of an
+// Accept-Encoding header.
+func parseEncoding(part string) (Encoding, bool) {
+	fields := strings.Split(part, ";")
+
+	coding := strings.TrimSpace(fields[0])
+	if coding == "" {
+		return Encoding{}, false
+	}
+	accepted := Encoding{Coding: coding, Q: 1}
+
+	for _, field := range fields[1:] {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) != "q" {
+			return Encoding{}, false
+		}
+		q, ok := parseQ(strings.TrimSpace(kv[1]))
+		if !ok {
+			return Encoding{}, false
+		}
+		accepted.Q = q
+	}
+
+	return accepted, true
+}
+
+// Less reports whether a should sort before b: higher quality first, then
+// a concrete coding before "*".
+func (a Encoding) Less(b Encoding) bool {
+	if a.Q != b.Q {
+		return a.Q > b.Q
+	}
+	return b.Coding == "*" && a.Coding != "*"
+}
+
+// Accepts reports whether coding is accepted by the EncodingSlice. The
+// comparison is case-insensitive, as content-coding tokens are. "identity"
+// is always acceptable unless explicitly disallowed with "identity;q=0" or
+// "*;q=0".
+func (accepted EncodingSlice) Accepts(coding string) bool {
+	for _, e := range accepted {
+		if strings.EqualFold(e.Coding, coding) {
+			return e.Q > 0
+		}
+	}
+	for _, e := range accepted {
+		if e.Coding == "*" {
+			return e.Q > 0
+		}
+	}
+	return strings.EqualFold(coding, "identity")
+}
+
+// NegotiateEncoding determines which of the given codings is the most
+// preferred, according to header. Codings are considered in order of the
+// header's precedence, and ties within the header are broken by the order
+// the codings were passed in. If no entry of header matches, a coding of
+// "identity" among codings is still returned when EncodingSlice.Accepts
+// allows it; "identity" is never returned unless it is among codings. If
+// codings is empty, or none of
+// them are acceptable, NegotiateEncoding returns an empty string and a
+// nil error.
+func NegotiateEncoding(header string, codings ...string) (string, error) {
+	if len(codings) == 0 {
+		return "", nil
+	}
+
+	accepted := ParseEncoding(header)
+	for _, a := range accepted {
+		if a.Q == 0 {
+			continue
+		}
+		for _, c := range codings {
+			if a.Coding == "*" || strings.EqualFold(a.Coding, c) {
+				return c, nil
+			}
+		}
+	}
+
+	for _, c := range codings {
+		if strings.EqualFold(c, "identity") && accepted.Accepts("identity") {
+			return c, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import "testing"
+
+func TestParseEncoding(t *testing.T) {
+	type parseTest struct {
+		input  string
+		output EncodingSlice
+	}
+
+	parseTests := []parseTest{
+		{ // 0
+			// Empty/not sent header signals that everything is accepted.
+			input:  "",
+			output: EncodingSlice{{Coding: "*", Q: 1}},
+		},
+		{ // 1
+			input: "gzip;q=1.0, identity; q=0.5, *;q=0",
+			output: EncodingSlice{
+				{Coding: "gzip", Q: 1},
+				{Coding: "identity", Q: 0.5},
+				{Coding: "*", Q: 0},
+			},
+		},
+		{ // 2
+			// Ties are broken by preferring a concrete coding over "*".
+			input: "*;q=0.8, br;q=0.8, gzip;q=0.8",
+			output: EncodingSlice{
+				{Coding: "br", Q: 0.8},
+				{Coding: "gzip", Q: 0.8},
+				{Coding: "*", Q: 0.8},
+			},
+		},
+	}
+
+	for testPos, test := range parseTests {
+		accepted := ParseEncoding(test.input)
+		if len(accepted) != len(test.output) {
+			t.Errorf("ParseEncoding (%d): expected %d elements, received %d.", testPos, len(test.output), len(accepted))
+			continue
+		}
+		for i, e := range accepted {
+			if e.Coding != test.output[i].Coding || e.Q != test.output[i].Q {
+				t.Errorf("ParseEncoding (%d.%d): expected '%v;q=%v', received '%v;q=%v'.", testPos, i, test.output[i].Coding, test.output[i].Q, e.Coding, e.Q)
+			}
+		}
+	}
+}
+
+func TestEncodingAccepts(t *testing.T) {
+	accepted := ParseEncoding("GZIP, Br;q=0.5")
+
+	acceptsTrue := []string{"gzip", "GZIP", "br", "identity"}
+	for i, coding := range acceptsTrue {
+		if !accepted.Accepts(coding) {
+			t.Errorf("Accepts (%d): expected '%v' to be accepted.", i, coding)
+		}
+	}
+
+	if accepted.Accepts("deflate") {
+		t.Errorf("Accepts: expected 'deflate' to not be accepted.")
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	type negotiateTest struct {
+		header   string
+		codings  []string
+		expected string
+	}
+
+	negotiateTests := []negotiateTest{
+		{ // 0
+			header:   "gzip, deflate, br",
+			codings:  []string{},
+			expected: "",
+		},
+		{ // 1
+			header:   "gzip;q=0.5, br;q=0.8",
+			codings:  []string{"gzip", "br"},
+			expected: "br",
+		},
+		{ // 2
+			// identity is implicitly acceptable even when the header does
+			// not list it.
+			header:   "gzip",
+			codings:  []string{"deflate", "identity"},
+			expected: "identity",
+		},
+		{ // 3
+			// An explicit "identity;q=0" removes the implicit fallback.
+			header:   "gzip, identity;q=0",
+			codings:  []string{"deflate", "identity"},
+			expected: "",
+		},
+		{ // 4
+			// Content-coding tokens are matched case-insensitively.
+			header:   "GZIP;q=0.5, BR;q=0.8",
+			codings:  []string{"gzip", "br"},
+			expected: "br",
+		},
+	}
+
+	for i, test := range negotiateTests {
+		result, err := NegotiateEncoding(test.header, test.codings...)
+		if err != nil {
+			t.Errorf("NegotiateEncoding (%d): expected no error, received '%v'.", i, err)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("NegotiateEncoding (%d): expected coding '%v', received '%v'.", i, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMalformedHeader is the error ParseError wraps, allowing callers to
+// test for it with errors.Is without depending on ParseError's fields.
+var ErrMalformedHeader = errors.New("accept: malformed header")
+
+// ParseErrorReason identifies why ParseStrict rejected a header.
+type ParseErrorReason string
+
+const (
+	// ReasonInvalidToken means a type, subtype, or parameter could not
+	// be parsed as a token or quoted-string.
+	ReasonInvalidToken ParseErrorReason = "invalid-token"
+	// ReasonInvalidQValue means a "q" parameter's value was not a
+	// valid, non-negative qvalue.
+	ReasonInvalidQValue ParseErrorReason = "invalid-qvalue"
+	// ReasonDuplicateParameter means the same parameter name appeared
+	// more than once within a single entry.
+	ReasonDuplicateParameter ParseErrorReason = "duplicate-parameter"
+	// ReasonUnterminatedQuotedString means a quoted-string parameter
+	// value was missing its closing quote.
+	ReasonUnterminatedQuotedString ParseErrorReason = "unterminated-quoted-string"
+)
+
+// ParseError reports why ParseStrict rejected a header, and where.
+// Position is the zero-based index of the comma-separated entry that
+// failed to parse; Offset is that entry's byte offset within the
+// original header; Fragment is the specific text that triggered Reason.
+type ParseError struct {
+	Position int
+	Offset   int
+	Fragment string
+	Reason   ParseErrorReason
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("accept: entry %d (offset %d): %s: %q", e.Position, e.Offset, e.Reason, e.Fragment)
+}
+
+// Unwrap allows errors.Is(err, ErrMalformedHeader) to succeed for any
+// ParseError.
+func (e *ParseError) Unwrap() error {
+	return ErrMalformedHeader
+}
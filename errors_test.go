@@ -0,0 +1,55 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseStrict(t *testing.T) {
+	type parseStrictTest struct {
+		input  string
+		reason ParseErrorReason
+	}
+
+	invalidTests := []parseStrictTest{
+		{"text/html;q=INVALID", ReasonInvalidQValue},
+		{"text/html;q=-1.05", ReasonInvalidQValue},
+		{"nosubtype", ReasonInvalidToken},
+		{`text/html;charset="unterminated`, ReasonUnterminatedQuotedString},
+		{"text/html;a=1;a=2", ReasonDuplicateParameter},
+		{"text/html;q=0.5;q=0.8", ReasonDuplicateParameter},
+	}
+	for i, test := range invalidTests {
+		accepted, err := ParseStrict(test.input)
+		if err == nil {
+			t.Errorf("ParseStrict (%d): expected an error, received none.", i)
+			continue
+		}
+		if accepted != nil {
+			t.Errorf("ParseStrict (%d): expected a nil AcceptSlice on error.", i)
+		}
+		if !errors.Is(err, ErrMalformedHeader) {
+			t.Errorf("ParseStrict (%d): expected errors.Is(err, ErrMalformedHeader) to hold.", i)
+		}
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("ParseStrict (%d): expected a *ParseError.", i)
+			continue
+		}
+		if parseErr.Reason != test.reason {
+			t.Errorf("ParseStrict (%d): expected reason '%v', received '%v'.", i, test.reason, parseErr.Reason)
+		}
+	}
+
+	accepted, err := ParseStrict("text/html, application/xml;q=0.9")
+	if err != nil {
+		t.Fatalf("ParseStrict: expected no error, received '%v'.", err)
+	}
+	if len(accepted) != 2 {
+		t.Errorf("ParseStrict: expected 2 elements, received %d.", len(accepted))
+	}
+}
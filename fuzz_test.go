@@ -0,0 +1,22 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import "testing"
+
+// FuzzParse asserts that Parse never panics, regardless of input.
+func FuzzParse(f *testing.F) {
+	f.Add(chromeHeader)
+	f.Add(firefoxHeader)
+	f.Add(curlHeader)
+	f.Add("")
+	f.Add(`text/html;charset="utf-8;foo";q=0.5`)
+	f.Add("text/html;q=1.05,application/xhtml+xml;q=-1.05,application/xml;q=1.0=0.5,*/*;q=INVALID")
+	f.Add(`text/html;charset="unterminated`)
+
+	f.Fuzz(func(t *testing.T, header string) {
+		Parse(header)
+	})
+}
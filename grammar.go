@@ -0,0 +1,154 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import "strings"
+
+// splitUnquoted splits s on sep, except for occurrences of sep inside a
+// quoted-string (RFC 7230 Section 3.2.6), where a backslash escapes the
+// following character. ok is false if s contains an unterminated
+// quoted-string.
+func splitUnquoted(s string, sep byte) (fields []string, ok bool) {
+	if strings.IndexByte(s, '"') < 0 {
+		// Fast path: without a quoted-string, sep can't be escaped, so a
+		// plain split is equivalent and avoids the byte-by-byte scan and
+		// its strings.Builder below.
+		return strings.Split(s, string(sep)), true
+	}
+
+	var b strings.Builder
+	inQuotes := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inQuotes {
+			b.WriteByte(c)
+			if c == '\\' && i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+				continue
+			}
+			if c == '"' {
+				inQuotes = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inQuotes = true
+			b.WriteByte(c)
+		case c == sep:
+			fields = append(fields, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if inQuotes {
+		return nil, false
+	}
+	fields = append(fields, b.String())
+	return fields, true
+}
+
+// isTokenChar reports whether c may appear in an RFC 7230 "token".
+func isTokenChar(c byte) bool {
+	if c <= 0x20 || c >= 0x7f {
+		return false
+	}
+	switch c {
+	case '(', ')', '<', '>', '@', ',', ';', ':', '\\', '"', '/', '[', ']', '?', '=', '{', '}':
+		return false
+	}
+	return true
+}
+
+// isToken reports whether s is a valid RFC 7230 "token".
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isTokenChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseParamValue parses the value half of a "key=value" parameter, which
+// is either a bare token or a quoted-string with backslash escapes.
+func parseParamValue(s string) (string, bool) {
+	if s == "" {
+		return "", false
+	}
+	if s[0] != '"' {
+		if !isToken(s) {
+			return "", false
+		}
+		return s, true
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+		case c == '"':
+			if i != len(s)-1 {
+				return "", false
+			}
+			return b.String(), true
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", false
+}
+
+// parseParam parses a single "key=value" parameter field, as produced by
+// splitUnquoted(s, ';'). The key is required to be a valid token; the
+// value may be a token or a quoted-string.
+func parseParam(field string) (key, value string, ok bool) {
+	eq := strings.IndexByte(field, '=')
+	if eq < 0 {
+		return "", "", false
+	}
+	key = strings.TrimSpace(field[:eq])
+	if !isToken(key) {
+		return "", "", false
+	}
+	value, ok = parseParamValue(strings.TrimSpace(field[eq+1:]))
+	if !ok {
+		return "", "", false
+	}
+	return key, value, true
+}
+
+// paramsMatch reports whether every parameter required by accepted is
+// present with an identical value in requested. An accepted list with no
+// parameters matches anything, e.g. a bare "text/html" Accept entry
+// matches an offer of "text/html;level=1".
+func paramsMatch(accepted, requested []Param) bool {
+	for _, a := range accepted {
+		value, ok := paramValue(requested, a.Key)
+		if !ok || value != a.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// paramValue returns the value associated with key in params, and whether
+// it was found.
+func paramValue(params []Param, key string) (string, bool) {
+	for _, p := range params {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,103 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package httpaccept provides net/http integration for content
+// negotiation using the accept package: middleware that negotiates a
+// response type from a request's Accept header, and helpers for
+// reporting a 406 Not Acceptable response.
+package httpaccept
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	accept "github.com/timewasted/go-accept-headers"
+)
+
+// FromRequest parses the Accept header of r into an accept.AcceptSlice.
+func FromRequest(r *http.Request) accept.AcceptSlice {
+	return accept.Parse(r.Header.Get("Accept"))
+}
+
+type contextKey int
+
+const negotiatedTypeKey contextKey = 0
+
+// NegotiatedType returns the type that Middleware negotiated for r, and
+// whether a type was negotiated at all.
+func NegotiatedType(r *http.Request) (string, bool) {
+	ctype, ok := r.Context().Value(negotiatedTypeKey).(string)
+	return ctype, ok
+}
+
+// Middleware negotiates a response type from each request's Accept
+// header against offers, storing the result in the request's context
+// (retrievable with NegotiatedType) and setting "Vary: Accept" on the
+// response. If none of offers are acceptable, it responds with 406 Not
+// Acceptable via WriteNotAcceptable instead of calling the wrapped
+// handler.
+func Middleware(offers ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept")
+
+			ctype, err := accept.NegotiateBest(r.Header.Get("Accept"), offers...)
+			if err != nil {
+				WriteNotAcceptable(w, offers)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), negotiatedTypeKey, ctype)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// notAcceptableBody is the machine-readable body written by
+// WriteNotAcceptable.
+type notAcceptableBody struct {
+	Supported []string `json:"supported"`
+}
+
+// WriteNotAcceptable writes a 406 Not Acceptable response whose JSON body
+// lists the types the server supports, per RFC 7231 Section 6.5.6.
+func WriteNotAcceptable(w http.ResponseWriter, offers []string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusNotAcceptable)
+	json.NewEncoder(w).Encode(notAcceptableBody{Supported: offers})
+}
+
+// ResponseWriter wraps an http.ResponseWriter, automatically setting the
+// Content-Type header to a negotiated type the first time WriteHeader or
+// Write is called, unless the handler has already set one explicitly.
+type ResponseWriter struct {
+	http.ResponseWriter
+	ContentType string
+
+	wroteHeader bool
+}
+
+// NewResponseWriter wraps w so that responses written through it get
+// Content-Type set to ctype.
+func NewResponseWriter(w http.ResponseWriter, ctype string) *ResponseWriter {
+	return &ResponseWriter{ResponseWriter: w, ContentType: ctype}
+}
+
+func (rw *ResponseWriter) WriteHeader(status int) {
+	if !rw.wroteHeader {
+		if rw.Header().Get("Content-Type") == "" {
+			rw.Header().Set("Content-Type", rw.ContentType)
+		}
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *ResponseWriter) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	return rw.ResponseWriter.Write(b)
+}
@@ -0,0 +1,94 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpaccept
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/html, application/json;q=0.9")
+
+	accepted := FromRequest(r)
+	if len(accepted) != 2 {
+		t.Fatalf("FromRequest: expected 2 elements, received %d.", len(accepted))
+	}
+	if accepted[0].Type != "text" || accepted[0].Subtype != "html" {
+		t.Errorf("FromRequest: expected first entry 'text/html', received '%v/%v'.", accepted[0].Type, accepted[0].Subtype)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	handler := Middleware("application/json", "text/html")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctype, ok := NegotiatedType(r)
+		if !ok {
+			t.Fatal("Middleware: expected a negotiated type in context, found none.")
+		}
+		w.Write([]byte(ctype))
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/html, application/json;q=0.5")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Middleware: expected status %d, received %d.", http.StatusOK, w.Code)
+	}
+	if got := w.Body.String(); got != "text/html" {
+		t.Errorf("Middleware: expected body 'text/html', received '%v'.", got)
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept" {
+		t.Errorf("Middleware: expected 'Vary: Accept', received 'Vary: %v'.", vary)
+	}
+}
+
+func TestMiddlewareNotAcceptable(t *testing.T) {
+	handler := Middleware("application/json")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("Middleware: handler should not have been called.")
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotAcceptable {
+		t.Fatalf("Middleware: expected status %d, received %d.", http.StatusNotAcceptable, w.Code)
+	}
+}
+
+func TestWriteNotAcceptable(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteNotAcceptable(w, []string{"application/json", "text/html"})
+
+	if w.Code != http.StatusNotAcceptable {
+		t.Fatalf("WriteNotAcceptable: expected status %d, received %d.", http.StatusNotAcceptable, w.Code)
+	}
+
+	var body struct {
+		Supported []string `json:"supported"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("WriteNotAcceptable: expected a valid JSON body, got error: %v", err)
+	}
+	if len(body.Supported) != 2 || body.Supported[0] != "application/json" || body.Supported[1] != "text/html" {
+		t.Errorf("WriteNotAcceptable: expected supported types '[application/json text/html]', received '%v'.", body.Supported)
+	}
+}
+
+func TestResponseWriter(t *testing.T) {
+	w := httptest.NewRecorder()
+	rw := NewResponseWriter(w, "application/json")
+	rw.Write([]byte(`{}`))
+
+	if ctype := w.Header().Get("Content-Type"); ctype != "application/json" {
+		t.Errorf("ResponseWriter: expected Content-Type 'application/json', received '%v'.", ctype)
+	}
+}
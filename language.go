@@ -0,0 +1,130 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import (
+	"sort"
+	"strings"
+)
+
+// Language represents a single entry of a parsed Accept-Language header.
+type Language struct {
+	Tag string
+	Q   float64
+}
+
+// LanguageSlice is a slice of Language entries, sorted by precedence:
+// highest quality first, with ties broken by specificity (a concrete tag
+// before "*").
+type LanguageSlice []Language
+
+// ParseLanguage parses a raw Accept-Language header value into a
+// LanguageSlice, sorted by precedence. Entries that cannot be parsed are
+// silently discarded. An empty header is treated as "*", meaning every
+// language is accepted.
+func ParseLanguage(header string) LanguageSlice {
+	parts := strings.Split(header, ",")
+	accepted := make(LanguageSlice, 0, len(parts))
+	for _, part := range parts {
+		l, ok := parseLanguage(part)
+		if !ok {
+			continue
+		}
+		accepted = append(accepted, l)
+	}
+	if header == "" {
+		accepted = append(accepted, Language{Tag: "*", Q: 1})
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].Less(accepted[j]) })
+	return accepted
+}
+
+// parseLanguage parses a single comma-separated entry of an
+// Accept-Language header.
+func parseLanguage(part string) (Language, bool) {
+	fields := strings.Split(part, ";")
+
+	tag := strings.TrimSpace(fields[0])
+	if tag == "" {
+		return Language{}, false
+	}
+	accepted := Language{Tag: tag, Q: 1}
+
+	for _, field := range fields[1:] {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) != "q" {
+			return Language{}, false
+		}
+		q, ok := parseQ(strings.TrimSpace(kv[1]))
+		if !ok {
+			return Language{}, false
+		}
+		accepted.Q = q
+	}
+
+	return accepted, true
+}
+
+// Less reports whether a should sort before b: higher quality first, then
+// a concrete tag before "*".
+func (a Language) Less(b Language) bool {
+	if a.Q != b.Q {
+		return a.Q > b.Q
+	}
+	return b.Tag == "*" && a.Tag != "*"
+}
+
+// languageMatches reports whether the BCP 47 tag accepted matches the
+// requested tag, where "*" matches anything and a primary subtag (e.g.
+// "en") matches any tag it prefixes (e.g. "en-US").
+func languageMatches(accepted, requested string) bool {
+	if accepted == "*" || requested == "*" {
+		return true
+	}
+	if strings.EqualFold(accepted, requested) {
+		return true
+	}
+	return len(accepted) < len(requested) &&
+		strings.EqualFold(requested[:len(accepted)], accepted) &&
+		requested[len(accepted)] == '-'
+}
+
+// Accepts reports whether tag is accepted by the LanguageSlice, using BCP
+// 47 subtag prefix matching, e.g. an Accept-Language of "en" accepts a
+// tag of "en-US".
+func (accepted LanguageSlice) Accepts(tag string) bool {
+	for _, l := range accepted {
+		if languageMatches(l.Tag, tag) {
+			return l.Q > 0
+		}
+	}
+	return false
+}
+
+// NegotiateLanguage determines which of the given tags is the most
+// preferred, according to header. Tags are considered in order of the
+// header's precedence, and ties within the header are broken by the
+// order the tags were passed in. If tags is empty, NegotiateLanguage
+// returns an empty string and a nil error.
+func NegotiateLanguage(header string, tags ...string) (string, error) {
+	if len(tags) == 0 {
+		return "", nil
+	}
+
+	accepted := ParseLanguage(header)
+	for _, a := range accepted {
+		if a.Q == 0 {
+			continue
+		}
+		for _, t := range tags {
+			if languageMatches(a.Tag, t) {
+				return t, nil
+			}
+		}
+	}
+
+	return "", nil
+}
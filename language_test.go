@@ -0,0 +1,99 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import "testing"
+
+func TestParseLanguage(t *testing.T) {
+	type parseTest struct {
+		input  string
+		output LanguageSlice
+	}
+
+	parseTests := []parseTest{
+		{ // 0
+			input:  "",
+			output: LanguageSlice{{Tag: "*", Q: 1}},
+		},
+		{ // 1
+			input: "da, en-gb;q=0.8, en;q=0.7, *;q=0.5",
+			output: LanguageSlice{
+				{Tag: "da", Q: 1},
+				{Tag: "en-gb", Q: 0.8},
+				{Tag: "en", Q: 0.7},
+				{Tag: "*", Q: 0.5},
+			},
+		},
+	}
+
+	for testPos, test := range parseTests {
+		accepted := ParseLanguage(test.input)
+		if len(accepted) != len(test.output) {
+			t.Errorf("ParseLanguage (%d): expected %d elements, received %d.", testPos, len(test.output), len(accepted))
+			continue
+		}
+		for i, l := range accepted {
+			if l.Tag != test.output[i].Tag || l.Q != test.output[i].Q {
+				t.Errorf("ParseLanguage (%d.%d): expected '%v;q=%v', received '%v;q=%v'.", testPos, i, test.output[i].Tag, test.output[i].Q, l.Tag, l.Q)
+			}
+		}
+	}
+}
+
+func TestLanguageAccepts(t *testing.T) {
+	accepted := ParseLanguage("en, fr-CA;q=0.8")
+
+	acceptsTrue := []string{"en", "en-US", "EN-gb", "fr-CA", "fr-ca"}
+	for i, tag := range acceptsTrue {
+		if !accepted.Accepts(tag) {
+			t.Errorf("Accepts (%d): expected '%v' to be accepted.", i, tag)
+		}
+	}
+
+	acceptsFalse := []string{"fr", "de", "fr-FR-x-foo"}
+	for i, tag := range acceptsFalse {
+		if accepted.Accepts(tag) {
+			t.Errorf("Accepts (%d): expected '%v' to not be accepted.", i, tag)
+		}
+	}
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+	type negotiateTest struct {
+		header   string
+		tags     []string
+		expected string
+	}
+
+	negotiateTests := []negotiateTest{
+		{ // 0
+			header:   "da, en-gb;q=0.8, en;q=0.7",
+			tags:     []string{},
+			expected: "",
+		},
+		{ // 1
+			// "en" is a prefix match for the offered "en-US".
+			header:   "en, fr;q=0.8",
+			tags:     []string{"fr-CA", "en-US"},
+			expected: "en-US",
+		},
+		{ // 2
+			header:   "de",
+			tags:     []string{"en-US", "fr-FR"},
+			expected: "",
+		},
+	}
+
+	for i, test := range negotiateTests {
+		result, err := NegotiateLanguage(test.header, test.tags...)
+		if err != nil {
+			t.Errorf("NegotiateLanguage (%d): expected no error, received '%v'.", i, err)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("NegotiateLanguage (%d): expected tag '%v', received '%v'.", i, test.expected, result)
+		}
+	}
+}
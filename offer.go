@@ -0,0 +1,90 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import "errors"
+
+// Offer represents a server-side media type preference, used by
+// NegotiateOffers to weigh the server's own preference alongside the
+// client's.
+type Offer struct {
+	Type string
+	Q    float64
+}
+
+// ErrEmptyOffers is returned by NegotiateOffers when no offers are given.
+var ErrEmptyOffers = errors.New("accept: no offers provided")
+
+// specificity scores an Accept entry by how concrete it is: 2 for a
+// fully concrete type/subtype, 1 for a partial wildcard (e.g. "text/*"),
+// and 0 for "*/*".
+func specificity(a Accept) int {
+	switch {
+	case a.Type != "*" && a.Subtype != "*":
+		return 2
+	case a.Type != "*" || a.Subtype != "*":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// NegotiateOffers determines which of the given offers is the best match
+// for header, picking the offer with the maximal client_q * server_q,
+// where client_q is the q-value of the Accept entry it matches and
+// server_q is the offer's own Q. Ties are broken first by the
+// specificity of the matching Accept entry (a concrete type/subtype
+// outranks a partial wildcard, which outranks "*/*"), then by the
+// client's ordering of Accept entries, then by the order offers was
+// given in.
+func NegotiateOffers(header string, offers []Offer) (string, error) {
+	if len(offers) == 0 {
+		return "", ErrEmptyOffers
+	}
+
+	accepted := Parse(header)
+
+	var (
+		found     bool
+		best      string
+		bestScore float64
+		bestSpec  int
+	)
+	for _, a := range accepted {
+		if a.Q == 0 {
+			continue
+		}
+		spec := specificity(a)
+		for _, o := range offers {
+			reqType, reqSubtype, reqParams := splitType(o.Type)
+			if !matches(a.Type, reqType) || !matches(a.Subtype, reqSubtype) || !paramsMatch(a.Parameters, reqParams) {
+				continue
+			}
+			score := a.Q * o.Q
+			if score == 0 {
+				continue
+			}
+			if !found || score > bestScore || (score == bestScore && spec > bestSpec) {
+				found, best, bestScore, bestSpec = true, o.Type, score, spec
+			}
+		}
+	}
+
+	if !found {
+		return "", ErrNoAcceptableType
+	}
+	return best, nil
+}
+
+// NegotiateBest is a convenience wrapper around NegotiateOffers for when
+// the server has no preference of its own among types, i.e. every type
+// is offered with an equal Q of 1.
+func NegotiateBest(header string, types ...string) (string, error) {
+	offers := make([]Offer, len(types))
+	for i, t := range types {
+		offers[i] = Offer{Type: t, Q: 1}
+	}
+	return NegotiateOffers(header, offers)
+}
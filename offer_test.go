@@ -0,0 +1,91 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import "testing"
+
+func TestNegotiateOffers(t *testing.T) {
+	type negotiateTest struct {
+		header   string
+		offers   []Offer
+		expected string
+	}
+
+	negotiateTests := []negotiateTest{
+		{ // 0
+			header:   "text/html, application/json",
+			offers:   nil,
+			expected: "",
+		},
+		{ // 1
+			// Equal server preference: the client's q-values decide.
+			header: "text/html, application/json;q=0.9",
+			offers: []Offer{
+				{Type: "application/json", Q: 1},
+				{Type: "text/html", Q: 1},
+			},
+			expected: "text/html",
+		},
+		{ // 2
+			// text/event-stream has a higher combined weight than
+			// application/json, even though the client listed it second.
+			header: "application/json, text/event-stream;q=0.9",
+			offers: []Offer{
+				{Type: "application/json", Q: 0.5},
+				{Type: "text/event-stream", Q: 1},
+			},
+			expected: "text/event-stream",
+		},
+		{ // 3
+			// Equal combined weight: the more specific Accept entry wins.
+			header: "text/*;q=0.3, text/html;q=0.6",
+			offers: []Offer{
+				{Type: "text/plain", Q: 1},
+				{Type: "text/html", Q: 0.5},
+			},
+			expected: "text/html",
+		},
+		{ // 4
+			header: "application/xml",
+			offers: []Offer{
+				{Type: "text/html", Q: 1},
+			},
+			expected: "",
+		},
+	}
+
+	for i, test := range negotiateTests {
+		result, err := NegotiateOffers(test.header, test.offers)
+		if len(test.offers) == 0 {
+			if err != ErrEmptyOffers {
+				t.Errorf("NegotiateOffers (%d): expected ErrEmptyOffers, received '%v'.", i, err)
+			}
+			continue
+		}
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("NegotiateOffers (%d): expected an error, received none.", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NegotiateOffers (%d): expected no error, received '%v'.", i, err)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("NegotiateOffers (%d): expected type '%v', received '%v'.", i, test.expected, result)
+		}
+	}
+}
+
+func TestNegotiateBest(t *testing.T) {
+	result, err := NegotiateBest("text/html, application/xhtml+xml, application/xml;q=0.9, */*;q=0.8", "application/xml", "text/html")
+	if err != nil {
+		t.Fatalf("NegotiateBest: expected no error, received '%v'.", err)
+	}
+	if result != "text/html" {
+		t.Errorf("NegotiateBest: expected type 'text/html', received '%v'.", result)
+	}
+}
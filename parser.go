@@ -0,0 +1,37 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import "sync"
+
+// Parser reduces allocations for repeated Accept header parsing by
+// reusing AcceptSlice backing storage across calls via a sync.Pool. A
+// zero-value Parser is ready to use.
+type Parser struct {
+	pool sync.Pool
+}
+
+// Parse parses header into a pooled AcceptSlice. The result is only valid
+// until Release is called with it; callers that need to retain it beyond
+// that must copy it first.
+func (p *Parser) Parse(header string) *AcceptSlice {
+	dst, _ := p.pool.Get().(*AcceptSlice)
+	if dst == nil {
+		dst = new(AcceptSlice)
+	}
+	ParseInto(header, dst)
+	return dst
+}
+
+// Release returns accepted's backing storage to the pool for reuse by a
+// future call to Parse. Do not use accepted after calling Release.
+//
+// accepted must be the same pointer Parse returned; passing any other
+// *AcceptSlice defeats the pool by forcing Parse to allocate a new one,
+// without causing incorrect behavior.
+func (p *Parser) Release(accepted *AcceptSlice) {
+	*accepted = (*accepted)[:0]
+	p.pool.Put(accepted)
+}
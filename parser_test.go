@@ -0,0 +1,58 @@
+// Copyright 2013 Ryan Rogers. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package accept
+
+import "testing"
+
+func TestParser(t *testing.T) {
+	var p Parser
+
+	accepted := p.Parse("text/html, application/json;q=0.9")
+	if len(*accepted) != 2 {
+		t.Fatalf("Parser.Parse: expected 2 elements, received %d.", len(*accepted))
+	}
+	if (*accepted)[0].Type != "text" || (*accepted)[0].Subtype != "html" {
+		t.Errorf("Parser.Parse: expected first entry 'text/html', received '%v/%v'.", (*accepted)[0].Type, (*accepted)[0].Subtype)
+	}
+	backing := *accepted
+	p.Release(accepted)
+
+	// Parsing again should reuse the same backing storage.
+	accepted = p.Parse("*/*")
+	if len(*accepted) != 1 || (*accepted)[0].Type != "*" {
+		t.Errorf("Parser.Parse: expected a single '*' entry, received %v.", *accepted)
+	}
+	if &backing[:1][0] != &(*accepted)[:1][0] {
+		t.Errorf("Parser.Parse: expected the backing array to be reused.")
+	}
+	p.Release(accepted)
+}
+
+func TestParseInto(t *testing.T) {
+	var dst AcceptSlice
+	if err := ParseInto("text/html, application/json;q=0.9", &dst); err != nil {
+		t.Fatalf("ParseInto: expected no error, received '%v'.", err)
+	}
+	if len(dst) != 2 {
+		t.Fatalf("ParseInto: expected 2 elements, received %d.", len(dst))
+	}
+
+	backing := dst
+	if err := ParseInto("*/*", &dst); err != nil {
+		t.Fatalf("ParseInto: expected no error, received '%v'.", err)
+	}
+	if len(dst) != 1 {
+		t.Fatalf("ParseInto: expected 1 element, received %d.", len(dst))
+	}
+	if &backing[:1][0] != &dst[:1][0] {
+		t.Errorf("ParseInto: expected the backing array to be reused.")
+	}
+
+	if err := ParseInto(`text/html;charset="unterminated`, &dst); err == nil {
+		t.Error("ParseInto: expected an error for an unterminated quoted string, received none.")
+	} else if len(dst) != 0 {
+		t.Errorf("ParseInto: expected dst to be truncated on error, received %v.", dst)
+	}
+}